@@ -0,0 +1,173 @@
+package blschia
+
+import "sync"
+
+// Aggregator buffers PublicKeys to be securely aggregated, without
+// requiring the caller to hold every key in a slice of their own as
+// PublicKeyAggregate does.
+//
+// PublicKeyAggregate's rogue-key defense derives each key's exponent from a
+// hash of the *entire* key set being aggregated, so the expensive part of
+// the computation cannot be done incrementally as keys arrive, or sharded
+// across goroutines: adding or moving one key changes every other key's
+// exponent too. Add and AddBatch therefore only append to a buffer; the
+// real aggregation work happens once, in Result, with a single call to
+// PublicKeyAggregate. There is no parallel variant of Aggregator — unlike
+// SignatureAggregator and AggregateSignaturesParallel below, for which
+// signature aggregation's lack of hash-weighting makes both safe
+type Aggregator struct {
+	mu   sync.Mutex
+	keys []*PublicKey
+}
+
+// NewAggregator returns an empty Aggregator ready to accept keys via Add or
+// AddBatch
+func NewAggregator() *Aggregator {
+	return &Aggregator{}
+}
+
+// Add buffers pk to be included the next time Result is called
+func (a *Aggregator) Add(pk *PublicKey) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.keys = append(a.keys, pk)
+}
+
+// AddBatch buffers every key in pks to be included the next time Result is
+// called
+func (a *Aggregator) AddBatch(pks []*PublicKey) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.keys = append(a.keys, pks...)
+}
+
+// Result securely aggregates every key added so far and returns the
+// combined PublicKey. It returns an error if no keys have been added
+func (a *Aggregator) Result() (*PublicKey, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return PublicKeyAggregate(a.keys)
+}
+
+// Reset discards every key added so far so the Aggregator can be reused
+func (a *Aggregator) Reset() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.keys = nil
+}
+
+// SignatureAggregator incrementally folds Signatures into a single
+// aggregated Signature.
+//
+// Unlike PublicKeyAggregate's rogue-key-resistant scheme, BLS signature
+// aggregation is plain G2 point addition, which is associative: Add and
+// AddBatch fold each new signature into a running aggregate with a single
+// 2-element SignatureAggregate call, so Result never has to re-aggregate
+// signatures already folded in
+type SignatureAggregator struct {
+	mu      sync.Mutex
+	running *Signature
+}
+
+// NewSignatureAggregator returns an empty SignatureAggregator ready to
+// accept signatures via Add or AddBatch
+func NewSignatureAggregator() *SignatureAggregator {
+	return &SignatureAggregator{}
+}
+
+// Add folds sig into the running aggregate
+func (a *SignatureAggregator) Add(sig *Signature) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.foldLocked(sig)
+}
+
+// AddBatch folds every signature in sigs into the running aggregate
+func (a *SignatureAggregator) AddBatch(sigs []*Signature) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, sig := range sigs {
+		if err := a.foldLocked(sig); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *SignatureAggregator) foldLocked(sig *Signature) error {
+	if a.running == nil {
+		a.running = sig
+		return nil
+	}
+	combined, err := SignatureAggregate([]*Signature{a.running, sig})
+	if err != nil {
+		return err
+	}
+	a.running = combined
+	return nil
+}
+
+// Result returns the aggregate of every signature folded in so far. It
+// returns an error if no signatures have been added
+func (a *SignatureAggregator) Result() (*Signature, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.running == nil {
+		// Delegate to SignatureAggregate for the empty case so callers
+		// see the same "no signatures" error it already raises
+		return SignatureAggregate(nil)
+	}
+	return a.running, nil
+}
+
+// Reset discards the running aggregate so the SignatureAggregator can be
+// reused
+func (a *SignatureAggregator) Reset() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.running = nil
+}
+
+// AggregateSignaturesParallel aggregates sigs using workers goroutines.
+//
+// This is safe where sharding PublicKeyAggregate would not be: BLS
+// signature aggregation is associative G2 point addition with no
+// hash-weighting step, so splitting sigs into shards, aggregating each
+// shard concurrently, and combining the per-shard results always produces
+// the same Signature as a single SignatureAggregate(sigs) call. For small
+// inputs or workers <= 1 it falls back to SignatureAggregate directly
+func AggregateSignaturesParallel(sigs []*Signature, workers int) (*Signature, error) {
+	if workers <= 1 || len(sigs) <= workers {
+		return SignatureAggregate(sigs)
+	}
+
+	shardSize := (len(sigs) + workers - 1) / workers
+	numShards := (len(sigs) + shardSize - 1) / shardSize
+
+	results := make([]*Signature, numShards)
+	errs := make([]error, numShards)
+
+	var wg sync.WaitGroup
+	for i := 0; i < numShards; i++ {
+		start := i * shardSize
+		end := start + shardSize
+		if end > len(sigs) {
+			end = len(sigs)
+		}
+
+		wg.Add(1)
+		go func(shardIdx int, shard []*Signature) {
+			defer wg.Done()
+			results[shardIdx], errs[shardIdx] = SignatureAggregate(shard)
+		}(i, sigs[start:end])
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return SignatureAggregate(results)
+}
@@ -0,0 +1,64 @@
+package blschia
+
+import "testing"
+
+func testSignatures(t *testing.T, msg []byte, n byte) []*Signature {
+	t.Helper()
+	sigs := make([]*Signature, 0, n)
+	for i := byte(1); i <= n; i++ {
+		seed := make([]byte, 32)
+		seed[0] = i
+		sk := ExtendedPrivateKeyFromSeed(seed).GetPrivateKey()
+		sigs = append(sigs, sk.Sign(msg))
+	}
+	return sigs
+}
+
+// TestSignatureAggregatorIncrementalFold checks that the running aggregate
+// built up by folding signatures in one Add at a time matches a single
+// SignatureAggregate call over the same signatures
+func TestSignatureAggregatorIncrementalFold(t *testing.T) {
+	sigs := testSignatures(t, []byte("aggregator fold test"), 6)
+
+	want, err := SignatureAggregate(sigs)
+	if err != nil {
+		t.Fatalf("SignatureAggregate: %v", err)
+	}
+
+	agg := NewSignatureAggregator()
+	for _, sig := range sigs {
+		if err := agg.Add(sig); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+	got, err := agg.Result()
+	if err != nil {
+		t.Fatalf("Result: %v", err)
+	}
+
+	if !got.Equal(want) {
+		t.Fatal("SignatureAggregator.Result() != SignatureAggregate(sigs)")
+	}
+}
+
+// TestAggregateSignaturesParallelMatchesSignatureAggregate guards
+// signature aggregation's sharded parallel path, which (unlike
+// PublicKeyAggregate's rogue-key-resistant scheme) is safe to split: it
+// must always equal a single SignatureAggregate call
+func TestAggregateSignaturesParallelMatchesSignatureAggregate(t *testing.T) {
+	sigs := testSignatures(t, []byte("parallel aggregate test"), 8)
+
+	want, err := SignatureAggregate(sigs)
+	if err != nil {
+		t.Fatalf("SignatureAggregate: %v", err)
+	}
+
+	got, err := AggregateSignaturesParallel(sigs, 4)
+	if err != nil {
+		t.Fatalf("AggregateSignaturesParallel: %v", err)
+	}
+
+	if !got.Equal(want) {
+		t.Fatal("AggregateSignaturesParallel(sigs, 4) != SignatureAggregate(sigs)")
+	}
+}
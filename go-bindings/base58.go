@@ -0,0 +1,76 @@
+package blschia
+
+import (
+	"math/big"
+)
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+var base58Radix = big.NewInt(58)
+
+// base58Encode encodes data using the Bitcoin base58 alphabet, preserving
+// leading zero bytes as leading '1' characters
+func base58Encode(data []byte) string {
+	x := new(big.Int).SetBytes(data)
+	mod := new(big.Int)
+	zero := big.NewInt(0)
+
+	var encoded []byte
+	for x.Cmp(zero) > 0 {
+		x.DivMod(x, base58Radix, mod)
+		encoded = append(encoded, base58Alphabet[mod.Int64()])
+	}
+
+	// Preserve leading zero bytes as '1's
+	for _, b := range data {
+		if b != 0 {
+			break
+		}
+		encoded = append(encoded, base58Alphabet[0])
+	}
+
+	// Reverse
+	for i, j := 0, len(encoded)-1; i < j; i, j = i+1, j-1 {
+		encoded[i], encoded[j] = encoded[j], encoded[i]
+	}
+
+	return string(encoded)
+}
+
+// base58Decode decodes a base58-encoded string back into bytes, restoring
+// any leading zero bytes represented by leading '1' characters
+func base58Decode(s string) ([]byte, error) {
+	x := big.NewInt(0)
+	for _, r := range s {
+		idx := indexByte(base58Alphabet, byte(r))
+		if idx < 0 {
+			return nil, errInvalidBase58Char
+		}
+		x.Mul(x, base58Radix)
+		x.Add(x, big.NewInt(int64(idx)))
+	}
+
+	decoded := x.Bytes()
+
+	// Restore leading zero bytes
+	var leadingZeros int
+	for _, r := range s {
+		if r != rune(base58Alphabet[0]) {
+			break
+		}
+		leadingZeros++
+	}
+
+	out := make([]byte, leadingZeros+len(decoded))
+	copy(out[leadingZeros:], decoded)
+	return out, nil
+}
+
+func indexByte(alphabet string, b byte) int {
+	for i := 0; i < len(alphabet); i++ {
+		if alphabet[i] == b {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,53 @@
+package blschia
+
+import (
+	"crypto/sha256"
+	"errors"
+)
+
+const base58CheckChecksumLen = 4
+
+var (
+	errInvalidBase58Char = errors.New("blschia: invalid base58 character")
+	errChecksumMismatch  = errors.New("blschia: base58check checksum mismatch")
+	errInvalidLength     = errors.New("blschia: invalid base58check payload length")
+)
+
+// doubleSHA256 returns SHA256(SHA256(data)), as used for base58check
+// checksums
+func doubleSHA256(data []byte) []byte {
+	first := sha256.Sum256(data)
+	second := sha256.Sum256(first[:])
+	return second[:]
+}
+
+// base58CheckEncode appends a 4-byte double-SHA256 checksum to payload and
+// base58-encodes the result
+func base58CheckEncode(payload []byte) string {
+	checksum := doubleSHA256(payload)[:base58CheckChecksumLen]
+	return base58Encode(append(append([]byte{}, payload...), checksum...))
+}
+
+// base58CheckDecode base58-decodes s and verifies its trailing 4-byte
+// checksum, returning the payload with the checksum stripped
+func base58CheckDecode(s string) ([]byte, error) {
+	decoded, err := base58Decode(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(decoded) < base58CheckChecksumLen {
+		return nil, errInvalidLength
+	}
+
+	payload := decoded[:len(decoded)-base58CheckChecksumLen]
+	checksum := decoded[len(decoded)-base58CheckChecksumLen:]
+	expected := doubleSHA256(payload)[:base58CheckChecksumLen]
+
+	for i := range checksum {
+		if checksum[i] != expected[i] {
+			return nil, errChecksumMismatch
+		}
+	}
+
+	return payload, nil
+}
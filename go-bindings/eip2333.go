@@ -0,0 +1,195 @@
+package blschia
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+// blsCurveOrder is r, the order of the BLS12-381 G1/G2 subgroups, used to
+// reduce HKDF output into a valid scalar
+var blsCurveOrder, _ = new(big.Int).SetString(
+	"73eda753299d7d483339d80809a1d80553bda402fffe5bf0ffffffff00000001", 16)
+
+const (
+	eip2333SaltPrefix = "BLS-SIG-KEYGEN-SALT-"
+	eip2333L          = 48
+)
+
+// hkdfModR runs the EIP-2333 HKDF_mod_r loop against ikm || 0x00 and salt,
+// expanding L=48 bytes and reducing modulo the BLS curve order. Per the
+// spec, salt is replaced with SHA-256(salt) at the *top* of every
+// iteration, including the first, so the first HKDF-Extract always runs
+// against a hashed salt; it retries with the salt hashed again if the
+// reduced result is zero
+func hkdfModR(ikm, salt []byte) *big.Int {
+	ikm = append(append([]byte{}, ikm...), 0x00)
+	for {
+		next := sha256.Sum256(salt)
+		salt = next[:]
+
+		okm := make([]byte, eip2333L)
+		reader := hkdf.New(sha256.New, ikm, salt, []byte{0, byte(eip2333L)})
+		if _, err := io.ReadFull(reader, okm); err != nil {
+			panic(err)
+		}
+
+		sk := new(big.Int).Mod(new(big.Int).SetBytes(okm), blsCurveOrder)
+		if sk.Sign() != 0 {
+			return sk
+		}
+	}
+}
+
+// skToBytes32 renders sk as a big-endian, zero-padded 32-byte scalar
+func skToBytes32(sk *big.Int) []byte {
+	out := make([]byte, 32)
+	sk.FillBytes(out)
+	return out
+}
+
+// EIP2333DeriveMaster implements derive_master_SK(seed) from EIP-2333: it
+// runs HKDF_mod_r with IKM=seed||0x00 and the fixed salt
+// "BLS-SIG-KEYGEN-SALT-", reducing the expanded output modulo the curve
+// order and retrying with an updated salt if the result is zero
+func EIP2333DeriveMaster(seed []byte) *PrivateKey {
+	sk := hkdfModR(seed, []byte(eip2333SaltPrefix))
+
+	key, err := PrivateKeyFromBytes(skToBytes32(sk), true)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+// parentLamportPK builds the two 255-entry Lamport hash chains described by
+// EIP-2333's parent_SK_to_lamport_PK, using IKM = parent_SK (big-endian) and
+// salt = index (big-endian), then SHA-256-compresses their concatenation
+func parentLamportPK(parentSK *big.Int, index uint32) []byte {
+	ikm := skToBytes32(parentSK)
+
+	var indexBytes [4]byte
+	binary.BigEndian.PutUint32(indexBytes[:], index)
+
+	lamport0 := ikmToLamportChain(ikm, indexBytes[:])
+
+	notIKM := make([]byte, len(ikm))
+	for i, b := range ikm {
+		notIKM[i] = ^b
+	}
+	lamport1 := ikmToLamportChain(notIKM, indexBytes[:])
+
+	compressed := sha256.New()
+	for _, chunk := range lamport0 {
+		h := sha256.Sum256(chunk)
+		compressed.Write(h[:])
+	}
+	for _, chunk := range lamport1 {
+		h := sha256.Sum256(chunk)
+		compressed.Write(h[:])
+	}
+
+	return compressed.Sum(nil)
+}
+
+// ikmToLamportChain expands ikm/salt into 255 32-byte Lamport chunks via
+// HKDF-Expand over L=255*32 bytes
+func ikmToLamportChain(ikm, salt []byte) [][]byte {
+	const chunks = 255
+	const chunkLen = 32
+
+	okm := make([]byte, chunks*chunkLen)
+	reader := hkdf.New(sha256.New, ikm, salt, nil)
+	if _, err := io.ReadFull(reader, okm); err != nil {
+		panic(err)
+	}
+
+	out := make([][]byte, chunks)
+	for i := 0; i < chunks; i++ {
+		out[i] = okm[i*chunkLen : (i+1)*chunkLen]
+	}
+	return out
+}
+
+// EIP2333DeriveChild implements derive_child_SK(parent_SK, index): it
+// compresses parent_SK's Lamport public key for index, then feeds that
+// compressed key through derive_master_SK to produce the child's private
+// key
+func EIP2333DeriveChild(parent *PrivateKey, index uint32) *PrivateKey {
+	var parentSK big.Int
+	parent.Serialize().WithBytes(func(b []byte) {
+		parentSK.SetBytes(b)
+	})
+
+	lamportPK := parentLamportPK(&parentSK, index)
+	sk := hkdfModR(lamportPK, []byte(eip2333SaltPrefix))
+
+	key, err := PrivateKeyFromBytes(skToBytes32(sk), true)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+// ParsePath2334 parses an EIP-2334 path string such as "m/12381/3600/0/0/0"
+// into its sequence of child indices.
+//
+// This is deliberately separate from ParsePath: BIP-32 paths reject any
+// segment >= 2^31 and add 2^31 to segments carrying a "'"/"h" suffix, but
+// EIP-2334 indices have no hardened/non-hardened distinction at all — they
+// span the full 0..2^32-1 range, and a "'"/"h" suffix is simply invalid
+// rather than silently shifting the index by 2^31. Sharing ParsePath would
+// make DerivePath2334 unable to reach indices >= 2^31 and mis-derive (by
+// +2^31) any path segment a caller marked hardened
+func ParsePath2334(path string) ([]uint32, error) {
+	segments := strings.Split(path, "/")
+	if len(segments) == 0 || (segments[0] != "m" && segments[0] != "M") {
+		return nil, pathError(path, ErrMalformedSegment, "path must start with \"m\" or \"M\"")
+	}
+
+	segments = segments[1:]
+	if len(segments) > 255 {
+		return nil, pathError(path, ErrPathTooDeep, "depth > 255")
+	}
+
+	indices := make([]uint32, 0, len(segments))
+	for _, segment := range segments {
+		if segment == "" {
+			return nil, pathError(path, ErrMalformedSegment, "malformed segment: empty path component")
+		}
+		if last := segment[len(segment)-1]; last == '\'' || last == 'h' || last == 'H' {
+			return nil, pathError(path, ErrHardenedNotApplicable,
+				fmt.Sprintf("malformed segment: %q has no hardened notion in EIP-2334 paths", segment))
+		}
+
+		index, err := strconv.ParseUint(segment, 10, 32)
+		if err != nil {
+			return nil, pathError(path, ErrMalformedSegment, fmt.Sprintf("malformed segment: %q", segment))
+		}
+		indices = append(indices, uint32(index))
+	}
+
+	return indices, nil
+}
+
+// DerivePath2334 walks path (an EIP-2334 path such as "m/12381/3600/0/0/0")
+// from master using EIP2333DeriveChild for every segment, parsing path
+// with ParsePath2334 rather than the BIP-32 ParsePath
+func DerivePath2334(master *PrivateKey, path string) (*PrivateKey, error) {
+	indices, err := ParsePath2334(path)
+	if err != nil {
+		return nil, err
+	}
+
+	current := master
+	for _, index := range indices {
+		current = EIP2333DeriveChild(current, index)
+	}
+	return current, nil
+}
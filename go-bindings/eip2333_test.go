@@ -0,0 +1,72 @@
+package blschia
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+)
+
+// TestEIP2333DeriveMasterOfficialVector pins derive_master_SK against the
+// official EIP-2333 test vector. It guards against regressing the salt
+// pre-hash: hkdfModR must hash "BLS-SIG-KEYGEN-SALT-" before the very first
+// HKDF-Extract, not only on zero-result retries, or every derived key here
+// would silently diverge from every other EIP-2333 implementation
+func TestEIP2333DeriveMasterOfficialVector(t *testing.T) {
+	seed, err := hex.DecodeString("c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04")
+	if err != nil {
+		t.Fatalf("decode seed: %v", err)
+	}
+
+	wantSK, ok := new(big.Int).SetString("6083874454709270928345386274498605044986640685124978867557563392430687146096", 10)
+	if !ok {
+		t.Fatal("failed to parse expected SK")
+	}
+
+	key := EIP2333DeriveMaster(seed)
+
+	var got *big.Int
+	key.Serialize().WithBytes(func(b []byte) {
+		got = new(big.Int).SetBytes(b)
+	})
+
+	if got.Cmp(wantSK) != 0 {
+		t.Fatalf("EIP2333DeriveMaster(seed) = %s, want %s", got, wantSK)
+	}
+}
+
+// TestParsePath2334AllowsFullUint32Range guards against DerivePath2334
+// sharing the BIP-32 ParsePath, which would reject any segment >= 2^31
+func TestParsePath2334AllowsFullUint32Range(t *testing.T) {
+	indices, err := ParsePath2334("m/12381/3600/2147483648/0/0")
+	if err != nil {
+		t.Fatalf("ParsePath2334: %v", err)
+	}
+
+	want := []uint32{12381, 3600, 1 << 31, 0, 0}
+	if len(indices) != len(want) {
+		t.Fatalf("got %d indices, want %d", len(indices), len(want))
+	}
+	for i, index := range indices {
+		if index != want[i] {
+			t.Fatalf("indices[%d] = %d, want %d", i, index, want[i])
+		}
+	}
+}
+
+// TestParsePath2334RejectsHardenedSuffix guards against silently
+// mis-deriving a path by +2^31 when a caller writes a "'"/"h" suffix that
+// has no meaning in EIP-2334
+func TestParsePath2334RejectsHardenedSuffix(t *testing.T) {
+	_, err := ParsePath2334("m/12381/3600/0'/0/0")
+	if err == nil {
+		t.Fatal("ParsePath2334 accepted a hardened segment, want an error")
+	}
+
+	pathErr, ok := err.(*PathError)
+	if !ok {
+		t.Fatalf("error type = %T, want *PathError", err)
+	}
+	if pathErr.Kind != ErrHardenedNotApplicable {
+		t.Fatalf("Kind = %v, want ErrHardenedNotApplicable", pathErr.Kind)
+	}
+}
@@ -10,8 +10,11 @@ package blschia
 // #include "blschia.h"
 import "C"
 import (
+	"encoding/binary"
 	"encoding/hex"
 	"runtime"
+
+	"github.com/tikhop/bls-signatures/go-bindings/mnemonic"
 )
 
 // ExtendedPrivateKey represents a BIP-32 style extended key, which is composed
@@ -34,6 +37,15 @@ func ExtendedPrivateKeyFromSeed(seed []byte) *ExtendedPrivateKey {
 	return &key
 }
 
+// ExtendedPrivateKeyFromMnemonic derives a master ExtendedPrivateKey directly
+// from a BIP-39 mnemonic phrase and passphrase, combining
+// mnemonic.MnemonicToSeed with ExtendedPrivateKeyFromSeed so callers don't
+// need to handle the intermediate 64-byte seed themselves
+func ExtendedPrivateKeyFromMnemonic(mnemonicPhrase, passphrase string) *ExtendedPrivateKey {
+	seed := mnemonic.MnemonicToSeed(mnemonicPhrase, passphrase)
+	return ExtendedPrivateKeyFromSeed(seed)
+}
+
 // ExtendedPrivateKeyFromBytes parses a private key and chain code from bytes
 func ExtendedPrivateKeyFromBytes(data []byte) *ExtendedPrivateKey {
 	// Get a C pointer to bytes
@@ -56,6 +68,60 @@ func ExtendedPrivateKeyFromString(hexString string) (*ExtendedPrivateKey, error)
 	return ExtendedPrivateKeyFromBytes(bytes), nil
 }
 
+// String returns the base58check-encoded representation of the
+// ExtendedPrivateKey, using the version bytes of the chia-mainnet network.
+// The payload is laid out as version(4) || depth(1) || parent
+// fingerprint(4) || child number(4) || chain code(32) || private key(32),
+// followed by a 4-byte double-SHA256 checksum
+func (key *ExtendedPrivateKey) String() string {
+	return key.StringForNetwork(chiaMainnet.Name)
+}
+
+// StringForNetwork is like String but encodes the version bytes registered
+// for the given network name instead of chia-mainnet's defaults
+func (key *ExtendedPrivateKey) StringForNetwork(network string) string {
+	params, ok := NetworkByName(network)
+	if !ok {
+		panic("blschia: unknown network " + network)
+	}
+
+	var encoded string
+	key.Serialize().WithBytes(func(raw []byte) {
+		payload := make([]byte, 0, len(raw))
+		var versionBytes [4]byte
+		binary.BigEndian.PutUint32(versionBytes[:], params.XprvVersion)
+		payload = append(payload, versionBytes[:]...)
+		payload = append(payload, raw[4:]...)
+
+		encoded = base58CheckEncode(payload)
+	})
+
+	return encoded
+}
+
+// ExtendedPrivateKeyFromBase58 parses a base58check-encoded extended private
+// key, verifying its checksum and mapping its version bytes back to a
+// registered network. It returns an error if the checksum is invalid, the
+// payload length is wrong, or the version bytes are unknown
+func ExtendedPrivateKeyFromBase58(encoded string) (*ExtendedPrivateKey, error) {
+	payload, err := base58CheckDecode(encoded)
+	if err != nil {
+		return nil, err
+	}
+	if len(payload) != 4+1+4+4+32+32 {
+		return nil, errInvalidLength
+	}
+
+	version := binary.BigEndian.Uint32(payload[0:4])
+	if _, err := networkByXprvVersion(version); err != nil {
+		return nil, err
+	}
+
+	// The native layout is identical to what ExtendedPrivateKeyFromBytes
+	// expects, so the decoded payload can be handed to it as-is
+	return ExtendedPrivateKeyFromBytes(payload), nil
+}
+
 // Free releases memory allocated by the key
 func (key *ExtendedPrivateKey) Free() {
 	C.CExtendedPrivateKeyFree(key.key)
@@ -63,12 +129,13 @@ func (key *ExtendedPrivateKey) Free() {
 }
 
 // Serialize returns the serialized byte representation of the
-// ExtendedPrivateKey object
-func (key *ExtendedPrivateKey) Serialize() []byte {
+// ExtendedPrivateKey object as a SecureBytes, since it contains private key
+// material
+func (key *ExtendedPrivateKey) Serialize() *SecureBytes {
 	ptr := C.CExtendedPrivateKeySerialize(key.key)
 	defer C.SecFree(ptr)
 	runtime.KeepAlive(key)
-	return C.GoBytes(ptr, C.CExtendedPrivateKeySizeBytes())
+	return newSecureBytes(C.GoBytes(ptr, C.CExtendedPrivateKeySizeBytes()))
 }
 
 // GetPublicKey returns the PublicKey which corresponds to the PrivateKey for
@@ -7,6 +7,7 @@ package blschia
 // #include "blschia.h"
 import "C"
 import (
+	"encoding/binary"
 	"encoding/hex"
 	"runtime"
 )
@@ -38,6 +39,54 @@ func ExtendedPublicKeyFromString(hexString string) (*ExtendedPublicKey, error) {
 	return ExtendedPublicKeyFromBytes(bytes), nil
 }
 
+// String returns the base58check-encoded representation of the
+// ExtendedPublicKey, using the version bytes of the chia-mainnet network.
+// The payload is laid out as version(4) || depth(1) || parent
+// fingerprint(4) || child number(4) || chain code(32) || public key,
+// followed by a 4-byte double-SHA256 checksum
+func (key *ExtendedPublicKey) String() string {
+	return key.StringForNetwork(chiaMainnet.Name)
+}
+
+// StringForNetwork is like String but encodes the version bytes registered
+// for the given network name instead of chia-mainnet's defaults
+func (key *ExtendedPublicKey) StringForNetwork(network string) string {
+	params, ok := NetworkByName(network)
+	if !ok {
+		panic("blschia: unknown network " + network)
+	}
+
+	raw := key.Serialize()
+	payload := make([]byte, 0, len(raw))
+	var versionBytes [4]byte
+	binary.BigEndian.PutUint32(versionBytes[:], params.XpubVersion)
+	payload = append(payload, versionBytes[:]...)
+	payload = append(payload, raw[4:]...)
+
+	return base58CheckEncode(payload)
+}
+
+// ExtendedPublicKeyFromBase58 parses a base58check-encoded extended public
+// key, verifying its checksum and mapping its version bytes back to a
+// registered network. It returns an error if the checksum is invalid or
+// the version bytes are unknown
+func ExtendedPublicKeyFromBase58(encoded string) (*ExtendedPublicKey, error) {
+	payload, err := base58CheckDecode(encoded)
+	if err != nil {
+		return nil, err
+	}
+	if len(payload) != 4+1+4+4+32+int(C.CPublicKeySizeBytes()) {
+		return nil, errInvalidLength
+	}
+
+	version := binary.BigEndian.Uint32(payload[0:4])
+	if _, err := networkByXpubVersion(version); err != nil {
+		return nil, err
+	}
+
+	return ExtendedPublicKeyFromBytes(payload), nil
+}
+
 // Free releases memory allocated by the key
 func (key *ExtendedPublicKey) Free() {
 	C.CExtendedPublicKeyFree(key.key)
@@ -0,0 +1,394 @@
+// Package keystore encrypts BLS private keys into Web3-Secret-Storage
+// compatible JSON files, so callers have a portable at-rest format instead
+// of hand-rolling one on top of PrivateKey.Serialize.
+package keystore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/crypto/sha3"
+
+	blschia "github.com/tikhop/bls-signatures/go-bindings"
+)
+
+// KDF selects the key derivation function used to stretch a passphrase
+// into the AES/MAC key material
+type KDF string
+
+const (
+	// KDFScrypt derives the key with scrypt (the default)
+	KDFScrypt KDF = "scrypt"
+	// KDFPBKDF2 derives the key with PBKDF2-HMAC-SHA256
+	KDFPBKDF2 KDF = "pbkdf2"
+)
+
+const (
+	defaultScryptN     = 262144
+	defaultScryptR     = 8
+	defaultScryptP     = 1
+	defaultPBKDF2Iters = 262144
+
+	derivedKeyLen = 32
+	aesKeyLen     = 16
+	saltLen       = 32
+	ivLen         = 16
+)
+
+// Options configures Encrypt. The zero value uses scrypt with the
+// package's default cost parameters
+type Options struct {
+	KDF        KDF
+	ScryptN    int
+	ScryptR    int
+	ScryptP    int
+	PBKDF2Iter int
+}
+
+func (o *Options) withDefaults() *Options {
+	if o == nil {
+		o = &Options{}
+	}
+	filled := *o
+	if filled.KDF == "" {
+		filled.KDF = KDFScrypt
+	}
+	if filled.ScryptN == 0 {
+		filled.ScryptN = defaultScryptN
+	}
+	if filled.ScryptR == 0 {
+		filled.ScryptR = defaultScryptR
+	}
+	if filled.ScryptP == 0 {
+		filled.ScryptP = defaultScryptP
+	}
+	if filled.PBKDF2Iter == 0 {
+		filled.PBKDF2Iter = defaultPBKDF2Iters
+	}
+	return &filled
+}
+
+// cipherParams mirrors the Web3 Secret Storage "cipherparams" object
+type cipherParams struct {
+	IV string `json:"iv"`
+}
+
+// scryptParams mirrors the Web3 Secret Storage scrypt "kdfparams" object
+type scryptParams struct {
+	DKLen int    `json:"dklen"`
+	N     int    `json:"n"`
+	R     int    `json:"r"`
+	P     int    `json:"p"`
+	Salt  string `json:"salt"`
+}
+
+// pbkdf2Params mirrors the Web3 Secret Storage pbkdf2 "kdfparams" object
+type pbkdf2Params struct {
+	DKLen int    `json:"dklen"`
+	C     int    `json:"c"`
+	PRF   string `json:"prf"`
+	Salt  string `json:"salt"`
+}
+
+// cryptoJSON mirrors the Web3 Secret Storage "crypto" object
+type cryptoJSON struct {
+	Cipher       string          `json:"cipher"`
+	CipherText   string          `json:"ciphertext"`
+	CipherParams cipherParams    `json:"cipherparams"`
+	KDF          KDF             `json:"kdf"`
+	KDFParams    json.RawMessage `json:"kdfparams"`
+	MAC          string          `json:"mac"`
+}
+
+// KeyType identifies what kind of BLS key a keystore document holds, since
+// Web3 Secret Storage itself has no notion of an HD extended key
+type KeyType string
+
+const (
+	// KeyTypePrivateKey marks a document produced by Encrypt, holding a
+	// plain PrivateKey. This is also the implied type of any document
+	// with no "keytype" field, for compatibility with keystores written
+	// before EncryptExtended existed
+	KeyTypePrivateKey KeyType = "privatekey"
+	// KeyTypeExtendedPrivateKey marks a document produced by
+	// EncryptExtended, holding a full ExtendedPrivateKey (chain code,
+	// depth, parent fingerprint, and child number included)
+	KeyTypeExtendedPrivateKey KeyType = "extendedprivatekey"
+)
+
+// keystoreJSON mirrors the top-level Web3 Secret Storage document, with an
+// added "keytype" field distinguishing a plain PrivateKey from a full
+// ExtendedPrivateKey
+type keystoreJSON struct {
+	Crypto  cryptoJSON `json:"crypto"`
+	Pubkey  string     `json:"pubkey"`
+	ID      string     `json:"id"`
+	Version int        `json:"version"`
+	KeyType KeyType    `json:"keytype,omitempty"`
+}
+
+// ErrInvalidPassphrase is returned by Decrypt when the passphrase doesn't
+// reproduce the stored MAC
+var ErrInvalidPassphrase = errors.New("keystore: invalid passphrase (MAC mismatch)")
+
+// Encrypt encrypts sk's serialized bytes into a Web3-Secret-Storage
+// compatible JSON document, protected by passphrase
+func Encrypt(sk *blschia.PrivateKey, passphrase string, opts *Options) ([]byte, error) {
+	var doc keystoreJSON
+	var err error
+	sk.Serialize().WithBytes(func(secret []byte) {
+		doc, err = encryptSecret(secret, sk.GetPublicKey().Serialize(), KeyTypePrivateKey, passphrase, opts)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(doc)
+}
+
+// EncryptExtended is like Encrypt, but encrypts an ExtendedPrivateKey's
+// full serialized form (version, depth, parent fingerprint, child number,
+// chain code, and private key) so DecryptExtended can recover an HD node
+// rather than just the bare private key
+func EncryptExtended(xprv *blschia.ExtendedPrivateKey, passphrase string, opts *Options) ([]byte, error) {
+	var doc keystoreJSON
+	var err error
+	xprv.Serialize().WithBytes(func(secret []byte) {
+		doc, err = encryptSecret(secret, xprv.GetExtendedPublicKey().Serialize(), KeyTypeExtendedPrivateKey, passphrase, opts)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(doc)
+}
+
+// encryptSecret holds the Web3 Secret Storage encryption logic shared by
+// Encrypt and EncryptExtended: derive key material from passphrase,
+// AES-128-CTR encrypt secret, and compute the Keccak-256 MAC
+func encryptSecret(secret, pubkey []byte, keyType KeyType, passphrase string, opts *Options) (keystoreJSON, error) {
+	opts = opts.withDefaults()
+
+	salt := make([]byte, saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return keystoreJSON{}, err
+	}
+	iv := make([]byte, ivLen)
+	if _, err := rand.Read(iv); err != nil {
+		return keystoreJSON{}, err
+	}
+
+	derivedKey, kdfParams, err := deriveKey(passphrase, salt, opts)
+	if err != nil {
+		return keystoreJSON{}, err
+	}
+
+	block, err := aes.NewCipher(derivedKey[:aesKeyLen])
+	if err != nil {
+		return keystoreJSON{}, err
+	}
+
+	ciphertext := make([]byte, len(secret))
+	cipher.NewCTR(block, iv).XORKeyStream(ciphertext, secret)
+
+	mac := keccak256(append(append([]byte{}, derivedKey[aesKeyLen:]...), ciphertext...))
+
+	return keystoreJSON{
+		Crypto: cryptoJSON{
+			Cipher:       "aes-128-ctr",
+			CipherText:   hex.EncodeToString(ciphertext),
+			CipherParams: cipherParams{IV: hex.EncodeToString(iv)},
+			KDF:          opts.KDF,
+			KDFParams:    kdfParams,
+			MAC:          hex.EncodeToString(mac),
+		},
+		Pubkey:  hex.EncodeToString(pubkey),
+		ID:      uuid.New().String(),
+		Version: 3,
+		KeyType: keyType,
+	}, nil
+}
+
+// Decrypt parses a Web3-Secret-Storage JSON document and recovers the
+// PrivateKey inside it, given the correct passphrase. It returns
+// ErrInvalidPassphrase if the passphrase doesn't reproduce the stored MAC,
+// or an error if the document holds an ExtendedPrivateKey instead (use
+// DecryptExtended for those)
+func Decrypt(jsonBlob []byte, passphrase string) (*blschia.PrivateKey, error) {
+	doc, secret, err := decryptDoc(jsonBlob, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	if doc.KeyType == KeyTypeExtendedPrivateKey {
+		return nil, fmt.Errorf("keystore: document holds an extendedprivatekey; use DecryptExtended")
+	}
+	defer zero(secret)
+
+	return blschia.PrivateKeyFromBytes(secret, true)
+}
+
+// DecryptExtended parses a Web3-Secret-Storage JSON document produced by
+// EncryptExtended and recovers the ExtendedPrivateKey inside it, given the
+// correct passphrase. It returns ErrInvalidPassphrase if the passphrase
+// doesn't reproduce the stored MAC, or an error if the document holds a
+// plain PrivateKey instead (use Decrypt for those)
+func DecryptExtended(jsonBlob []byte, passphrase string) (*blschia.ExtendedPrivateKey, error) {
+	doc, secret, err := decryptDoc(jsonBlob, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	if doc.KeyType != KeyTypeExtendedPrivateKey {
+		return nil, fmt.Errorf("keystore: document holds a %s; use Decrypt", KeyTypePrivateKey)
+	}
+	defer zero(secret)
+
+	return blschia.ExtendedPrivateKeyFromBytes(secret), nil
+}
+
+// decryptDoc parses jsonBlob, derives the key material, verifies the MAC,
+// and decrypts the ciphertext. It is shared by Decrypt and DecryptExtended,
+// which differ only in how they interpret the recovered secret bytes
+func decryptDoc(jsonBlob []byte, passphrase string) (keystoreJSON, []byte, error) {
+	var doc keystoreJSON
+	if err := json.Unmarshal(jsonBlob, &doc); err != nil {
+		return keystoreJSON{}, nil, err
+	}
+
+	ciphertext, err := hex.DecodeString(doc.Crypto.CipherText)
+	if err != nil {
+		return keystoreJSON{}, nil, err
+	}
+	iv, err := hex.DecodeString(doc.Crypto.CipherParams.IV)
+	if err != nil {
+		return keystoreJSON{}, nil, err
+	}
+	storedMAC, err := hex.DecodeString(doc.Crypto.MAC)
+	if err != nil {
+		return keystoreJSON{}, nil, err
+	}
+
+	derivedKey, err := deriveKeyFromParams(passphrase, doc.Crypto.KDF, doc.Crypto.KDFParams)
+	if err != nil {
+		return keystoreJSON{}, nil, err
+	}
+
+	mac := keccak256(append(append([]byte{}, derivedKey[aesKeyLen:]...), ciphertext...))
+	if !hmacEqual(mac, storedMAC) {
+		return keystoreJSON{}, nil, ErrInvalidPassphrase
+	}
+
+	block, err := aes.NewCipher(derivedKey[:aesKeyLen])
+	if err != nil {
+		return keystoreJSON{}, nil, err
+	}
+	secret := make([]byte, len(ciphertext))
+	cipher.NewCTR(block, iv).XORKeyStream(secret, ciphertext)
+
+	return doc, secret, nil
+}
+
+// zero overwrites b with zero bytes
+func zero(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
+func deriveKey(passphrase string, salt []byte, opts *Options) ([]byte, json.RawMessage, error) {
+	switch opts.KDF {
+	case KDFPBKDF2:
+		key := pbkdf2.Key([]byte(passphrase), salt, opts.PBKDF2Iter, derivedKeyLen, sha256.New)
+		params, err := json.Marshal(pbkdf2Params{
+			DKLen: derivedKeyLen,
+			C:     opts.PBKDF2Iter,
+			PRF:   "hmac-sha256",
+			Salt:  hex.EncodeToString(salt),
+		})
+		return key, params, err
+	case KDFScrypt, "":
+		key, err := scrypt.Key([]byte(passphrase), salt, opts.ScryptN, opts.ScryptR, opts.ScryptP, derivedKeyLen)
+		if err != nil {
+			return nil, nil, err
+		}
+		params, err := json.Marshal(scryptParams{
+			DKLen: derivedKeyLen,
+			N:     opts.ScryptN,
+			R:     opts.ScryptR,
+			P:     opts.ScryptP,
+			Salt:  hex.EncodeToString(salt),
+		})
+		return key, params, err
+	default:
+		return nil, nil, fmt.Errorf("keystore: unsupported kdf %q", opts.KDF)
+	}
+}
+
+func deriveKeyFromParams(passphrase string, kdf KDF, raw json.RawMessage) ([]byte, error) {
+	var (
+		key []byte
+		err error
+	)
+
+	switch kdf {
+	case KDFPBKDF2:
+		var params pbkdf2Params
+		if err := json.Unmarshal(raw, &params); err != nil {
+			return nil, err
+		}
+		salt, err := hex.DecodeString(params.Salt)
+		if err != nil {
+			return nil, err
+		}
+		key = pbkdf2.Key([]byte(passphrase), salt, params.C, params.DKLen, sha256.New)
+	case KDFScrypt, "":
+		var params scryptParams
+		if err := json.Unmarshal(raw, &params); err != nil {
+			return nil, err
+		}
+		salt, err := hex.DecodeString(params.Salt)
+		if err != nil {
+			return nil, err
+		}
+		key, err = scrypt.Key([]byte(passphrase), salt, params.N, params.R, params.P, params.DKLen)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("keystore: unsupported kdf %q", kdf)
+	}
+
+	// kdfparams.dklen is attacker-controlled once a keystore file is on
+	// disk; reject anything that wouldn't leave room for both the AES key
+	// and the MAC preimage halves derivedKey is later split into
+	if len(key) != derivedKeyLen {
+		return nil, fmt.Errorf("keystore: kdfparams dklen must be %d, got %d", derivedKeyLen, len(key))
+	}
+
+	return key, err
+}
+
+func keccak256(data []byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+func hmacEqual(a, b []byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	var diff byte
+	for i := range a {
+		diff |= a[i] ^ b[i]
+	}
+	return diff == 0
+}
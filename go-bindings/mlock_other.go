@@ -0,0 +1,9 @@
+//go:build !linux && !darwin
+
+package blschia
+
+// Lock is a no-op on platforms other than Linux and macOS, where mlock/
+// munlock are not wired up. It always returns a no-op unlock function
+func (s *SecureBytes) Lock() (unlock func(), err error) {
+	return func() {}, nil
+}
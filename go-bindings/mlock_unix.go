@@ -0,0 +1,19 @@
+//go:build linux || darwin
+
+package blschia
+
+import "golang.org/x/sys/unix"
+
+// Lock pins the secret's backing memory with mlock so it cannot be paged
+// to swap, and returns an unlock function that must be called (typically
+// via defer) once the caller is done with the secret. It is a no-op on
+// platforms other than Linux and macOS
+func (s *SecureBytes) Lock() (unlock func(), err error) {
+	if len(s.buf) == 0 {
+		return func() {}, nil
+	}
+	if err := unix.Mlock(s.buf); err != nil {
+		return nil, err
+	}
+	return func() { _ = unix.Munlock(s.buf) }, nil
+}
@@ -0,0 +1,178 @@
+// Package mnemonic implements BIP-39 mnemonic seed phrases: generating
+// entropy, encoding it as a word list, validating a phrase's checksum, and
+// stretching a phrase into the 64-byte seed used to derive an
+// ExtendedPrivateKey.
+package mnemonic
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// pbkdf2Iterations is the BIP-39 standard iteration count for
+	// deriving a seed from a mnemonic
+	pbkdf2Iterations = 2048
+	// seedLen is the number of bytes MnemonicToSeed produces
+	seedLen = 64
+)
+
+var (
+	// ErrInvalidEntropyLength is returned by NewEntropy when bits is not
+	// one of 128, 160, 192, 224, or 256
+	ErrInvalidEntropyLength = errors.New("mnemonic: entropy length must be one of 128, 160, 192, 224, 256 bits")
+	// ErrInvalidMnemonic describes a mnemonic that fails validation,
+	// either due to word count or checksum mismatch. ValidateMnemonic
+	// reports its result as a bool and does not return this error
+	ErrInvalidMnemonic = errors.New("mnemonic: invalid mnemonic")
+)
+
+// Wordlist is a BIP-39 wordlist: an ordered list of exactly 2048 words,
+// where a mnemonic word's position in the list encodes 11 bits of entropy
+type Wordlist interface {
+	// Words returns the 2048 words in order
+	Words() []string
+	// IndexOf returns the index of word in the list, or -1 if absent
+	IndexOf(word string) int
+}
+
+var registeredWordlists = map[string]Wordlist{}
+
+// RegisterWordlist makes a Wordlist available under name for lookup via
+// WordlistByName. NewMnemonic and ValidateMnemonic take the Wordlist itself
+// and do not consult this registry. The English wordlist is registered
+// under "english" automatically
+func RegisterWordlist(name string, list Wordlist) {
+	registeredWordlists[name] = list
+}
+
+// WordlistByName looks up a previously registered Wordlist
+func WordlistByName(name string) (Wordlist, bool) {
+	list, ok := registeredWordlists[name]
+	return list, ok
+}
+
+func init() {
+	RegisterWordlist("english", English)
+}
+
+// NewEntropy returns bits/8 bytes of cryptographically secure random
+// entropy suitable for NewMnemonic. bits must be one of 128, 160, 192,
+// 224, or 256, matching the BIP-39 supported mnemonic lengths of 12, 15,
+// 18, 21, and 24 words
+func NewEntropy(bits int) ([]byte, error) {
+	if bits < 128 || bits > 256 || bits%32 != 0 {
+		return nil, ErrInvalidEntropyLength
+	}
+
+	entropy := make([]byte, bits/8)
+	if _, err := rand.Read(entropy); err != nil {
+		return nil, err
+	}
+	return entropy, nil
+}
+
+// NewMnemonic encodes entropy as a space-separated BIP-39 mnemonic using
+// wordlist. entropy must be 16, 20, 24, 28, or 32 bytes, as produced by
+// NewEntropy. wordlist takes the same Wordlist interface as
+// ValidateMnemonic, rather than a raw slice, so both entry points agree on
+// how a wordlist is supplied
+func NewMnemonic(entropy []byte, wordlist Wordlist) (string, error) {
+	entropyBits := len(entropy) * 8
+	if entropyBits < 128 || entropyBits > 256 || entropyBits%32 != 0 {
+		return "", ErrInvalidEntropyLength
+	}
+	listWords := wordlist.Words()
+	if len(listWords) != 2048 {
+		return "", fmt.Errorf("mnemonic: wordlist must contain exactly 2048 words, got %d", len(listWords))
+	}
+
+	checksumBits := entropyBits / 32
+	checksum := sha256.Sum256(entropy)
+
+	// Concatenate entropy || checksum bits into a single bit string
+	bits := make([]bool, entropyBits+checksumBits)
+	for i := 0; i < entropyBits; i++ {
+		bits[i] = entropy[i/8]&(1<<uint(7-i%8)) != 0
+	}
+	for i := 0; i < checksumBits; i++ {
+		bits[entropyBits+i] = checksum[0]&(1<<uint(7-i)) != 0
+	}
+
+	numWords := len(bits) / 11
+	words := make([]string, numWords)
+	for i := 0; i < numWords; i++ {
+		var idx int
+		for j := 0; j < 11; j++ {
+			idx <<= 1
+			if bits[i*11+j] {
+				idx |= 1
+			}
+		}
+		words[i] = listWords[idx]
+	}
+
+	return strings.Join(words, " "), nil
+}
+
+// ValidateMnemonic reports whether mnemonic is a well-formed BIP-39
+// mnemonic under wordlist: correct word count and a matching checksum
+func ValidateMnemonic(mnemonic string, wordlist Wordlist) bool {
+	words := strings.Fields(mnemonic)
+	numWords := len(words)
+	if numWords < 12 || numWords > 24 || numWords%3 != 0 {
+		return false
+	}
+
+	totalBits := numWords * 11
+	entropyBits := totalBits * 32 / 33
+	checksumBits := totalBits - entropyBits
+
+	bits := make([]bool, totalBits)
+	for i, word := range words {
+		idx := wordlist.IndexOf(word)
+		if idx < 0 {
+			return false
+		}
+		for j := 0; j < 11; j++ {
+			bits[i*11+j] = idx&(1<<uint(10-j)) != 0
+		}
+	}
+
+	entropy := make([]byte, entropyBits/8)
+	for i := 0; i < entropyBits; i++ {
+		if bits[i] {
+			entropy[i/8] |= 1 << uint(7-i%8)
+		}
+	}
+
+	checksum := sha256.Sum256(entropy)
+	for i := 0; i < checksumBits; i++ {
+		want := checksum[0]&(1<<uint(7-i)) != 0
+		if bits[entropyBits+i] != want {
+			return false
+		}
+	}
+
+	return true
+}
+
+// MnemonicToSeed stretches mnemonic and an optional passphrase into a
+// 64-byte seed via PBKDF2-HMAC-SHA512 with 2048 iterations, as specified
+// by BIP-39. Both the mnemonic and the passphrase are NFKD-normalized
+// first, as BIP-39 requires, so passphrases containing accented or
+// multi-byte characters stretch to the same seed other BIP-39
+// implementations produce. It does not validate the mnemonic's checksum;
+// callers that need that should call ValidateMnemonic first
+func MnemonicToSeed(mnemonic, passphrase string) []byte {
+	normalized := norm.NFKD.String(strings.Join(strings.Fields(mnemonic), " "))
+	salt := "mnemonic" + norm.NFKD.String(passphrase)
+	return pbkdf2.Key([]byte(normalized), []byte(salt), pbkdf2Iterations, seedLen, sha512.New)
+}
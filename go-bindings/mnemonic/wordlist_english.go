@@ -0,0 +1,37 @@
+package mnemonic
+
+import (
+	_ "embed"
+	"strings"
+)
+
+//go:embed wordlist_english.txt
+var englishWordlistData string
+
+// englishWords is the standard BIP-39 English wordlist, in order
+var englishWords = strings.Split(strings.TrimSpace(englishWordlistData), "\n")
+
+// englishIndex maps each word to its position in englishWords
+var englishIndex = func() map[string]int {
+	m := make(map[string]int, len(englishWords))
+	for i, w := range englishWords {
+		m[w] = i
+	}
+	return m
+}()
+
+type englishWordlist struct{}
+
+func (englishWordlist) Words() []string {
+	return englishWords
+}
+
+func (englishWordlist) IndexOf(word string) int {
+	if idx, ok := englishIndex[word]; ok {
+		return idx
+	}
+	return -1
+}
+
+// English is the standard BIP-39 English wordlist
+var English Wordlist = englishWordlist{}
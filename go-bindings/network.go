@@ -0,0 +1,68 @@
+package blschia
+
+import "fmt"
+
+// NetworkParams describes the version bytes used when base58check-encoding
+// extended keys for a particular network, mirroring BIP-32's xprv/xpub
+// version prefixes
+type NetworkParams struct {
+	Name        string
+	XprvVersion uint32
+	XpubVersion uint32
+}
+
+// chiaMainnet mirrors Chia's own xprv/xpub version bytes so
+// ExtendedPrivateKey.String and ExtendedPublicKey.String work out of the
+// box without callers having to register a network first
+var chiaMainnet = NetworkParams{
+	Name:        "chia-mainnet",
+	XprvVersion: 0x01e19834,
+	XpubVersion: 0x01e19833,
+}
+
+var (
+	networksByName        = map[string]*NetworkParams{}
+	networksByXprvVersion = map[uint32]*NetworkParams{}
+	networksByXpubVersion = map[uint32]*NetworkParams{}
+)
+
+func init() {
+	RegisterNetwork(chiaMainnet.Name, chiaMainnet.XprvVersion, chiaMainnet.XpubVersion)
+}
+
+// RegisterNetwork registers a network's xprv/xpub version bytes so that
+// ExtendedPrivateKeyFromBase58 and ExtendedPublicKeyFromBase58 can recognize
+// them, and so String can be told which network to encode for
+func RegisterNetwork(name string, xprvVersion, xpubVersion uint32) *NetworkParams {
+	params := &NetworkParams{
+		Name:        name,
+		XprvVersion: xprvVersion,
+		XpubVersion: xpubVersion,
+	}
+	networksByName[name] = params
+	networksByXprvVersion[xprvVersion] = params
+	networksByXpubVersion[xpubVersion] = params
+	return params
+}
+
+// NetworkByName looks up a previously registered network by name
+func NetworkByName(name string) (*NetworkParams, bool) {
+	params, ok := networksByName[name]
+	return params, ok
+}
+
+func networkByXprvVersion(version uint32) (*NetworkParams, error) {
+	params, ok := networksByXprvVersion[version]
+	if !ok {
+		return nil, fmt.Errorf("blschia: unknown xprv version 0x%08x", version)
+	}
+	return params, nil
+}
+
+func networkByXpubVersion(version uint32) (*NetworkParams, error) {
+	params, ok := networksByXpubVersion[version]
+	if !ok {
+		return nil, fmt.Errorf("blschia: unknown xpub version 0x%08x", version)
+	}
+	return params, nil
+}
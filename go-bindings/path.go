@@ -0,0 +1,149 @@
+package blschia
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// hardenedOffset is added to a path segment's index when it carries a
+// hardened (') suffix, matching BIP-32
+const hardenedOffset = uint32(1) << 31
+
+// BLSPurpose is the EIP-2334 purpose field used by BLS wallets that derive
+// keys under m/12381/...
+const BLSPurpose = 12381
+
+// PathErrorKind identifies why a derivation path string could not be
+// parsed or followed, so callers can branch on the failure programmatically
+// instead of matching PathError.Reason as a free-form string
+type PathErrorKind int
+
+const (
+	// ErrMalformedSegment means a path segment wasn't "m"/"M", or wasn't a
+	// valid index: for ParsePath an unsigned 31-bit integer with an optional
+	// hardened suffix, for ParsePath2334 an unsigned 32-bit integer
+	ErrMalformedSegment PathErrorKind = iota
+	// ErrPathTooDeep means the path descends more than 255 levels
+	ErrPathTooDeep
+	// ErrHardenedFromPublic means the path requires deriving a hardened
+	// child from an ExtendedPublicKey, which is impossible
+	ErrHardenedFromPublic
+	// ErrHardenedNotApplicable means a segment carried a "'"/"h" suffix
+	// in an EIP-2334 path, which has no hardened/non-hardened distinction
+	ErrHardenedNotApplicable
+)
+
+// PathError describes why a derivation path string could not be parsed or
+// followed. Kind identifies the failure programmatically; Reason is the
+// human-readable detail embedded in Error()
+type PathError struct {
+	Path   string
+	Kind   PathErrorKind
+	Reason string
+}
+
+func (e *PathError) Error() string {
+	return fmt.Sprintf("blschia: invalid derivation path %q: %s", e.Path, e.Reason)
+}
+
+func pathError(path string, kind PathErrorKind, reason string) *PathError {
+	return &PathError{Path: path, Kind: kind, Reason: reason}
+}
+
+// ParsePath parses a derivation path string such as "m/12381/8444/0'/0" into
+// its sequence of child indices, applying the hardened offset to segments
+// suffixed with "'" or "h". It returns a *PathError if the path is
+// malformed or descends more than 255 levels
+func ParsePath(path string) ([]uint32, error) {
+	segments := strings.Split(path, "/")
+	if len(segments) == 0 || (segments[0] != "m" && segments[0] != "M") {
+		return nil, pathError(path, ErrMalformedSegment, "path must start with \"m\" or \"M\"")
+	}
+
+	segments = segments[1:]
+	if len(segments) > 255 {
+		return nil, pathError(path, ErrPathTooDeep, "depth > 255")
+	}
+
+	indices := make([]uint32, 0, len(segments))
+	for _, segment := range segments {
+		if segment == "" {
+			return nil, pathError(path, ErrMalformedSegment, "malformed segment: empty path component")
+		}
+
+		hardened := false
+		numeric := segment
+		if last := segment[len(segment)-1]; last == '\'' || last == 'h' || last == 'H' {
+			hardened = true
+			numeric = segment[:len(segment)-1]
+		}
+
+		index, err := strconv.ParseUint(numeric, 10, 32)
+		if err != nil || index >= uint64(hardenedOffset) {
+			return nil, pathError(path, ErrMalformedSegment, fmt.Sprintf("malformed segment: %q", segment))
+		}
+
+		if hardened {
+			index += uint64(hardenedOffset)
+		}
+		indices = append(indices, uint32(index))
+	}
+
+	return indices, nil
+}
+
+// MustParsePath is like ParsePath but panics if path cannot be parsed. It is
+// intended for use with compile-time-known paths
+func MustParsePath(path string) []uint32 {
+	indices, err := ParsePath(path)
+	if err != nil {
+		panic(err)
+	}
+	return indices
+}
+
+// BLSPath formats an EIP-2334-style derivation path
+// "m/12381/coinType/account/change/index" as used by many BLS wallets. Per
+// EIP-2334 every segment is written as a plain integer; none carry the "'"
+// hardened suffix, so the result round-trips through ParsePath/
+// DerivePath2334 without accidentally adding 2^31 to index. ParsePath
+// rejects the result if any argument is >= 2^31; ParsePath2334 accepts it
+func BLSPath(coinType, account, change, index uint32) string {
+	return fmt.Sprintf("m/%d/%d/%d/%d/%d", BLSPurpose, coinType, account, change, index)
+}
+
+// DeriveFromPath derives the ExtendedPrivateKey reached by following path
+// from key, applying PrivateChild once per path segment
+func (key *ExtendedPrivateKey) DeriveFromPath(path string) (*ExtendedPrivateKey, error) {
+	indices, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	current := key
+	for _, index := range indices {
+		current = current.PrivateChild(index)
+	}
+	return current, nil
+}
+
+// DeriveFromPath derives the ExtendedPublicKey reached by following path
+// from key, applying PublicChild once per path segment. It returns a
+// *PathError if path contains a hardened segment, since hardened children
+// cannot be derived from a public key
+func (key *ExtendedPublicKey) DeriveFromPath(path string) (*ExtendedPublicKey, error) {
+	indices, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	current := key
+	for _, index := range indices {
+		if index >= hardenedOffset {
+			return nil, pathError(path, ErrHardenedFromPublic, "hardened requested from xpub")
+		}
+		current = current.PublicChild(index)
+	}
+	return current, nil
+}
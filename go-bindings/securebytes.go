@@ -0,0 +1,52 @@
+package blschia
+
+import "runtime"
+
+// SecureBytes wraps a byte slice containing secret key material. Unlike a
+// plain []byte, it is zeroed automatically when garbage collected and
+// exposes WithBytes as the preferred way to read its contents, so secret
+// data doesn't leak into unmanaged []byte copies that the GC is free to
+// move or that a caller might accidentally retain
+type SecureBytes struct {
+	buf []byte
+}
+
+// newSecureBytes takes ownership of data, wrapping it in a SecureBytes that
+// zeroes itself when it is garbage collected
+func newSecureBytes(data []byte) *SecureBytes {
+	sb := &SecureBytes{buf: data}
+	runtime.SetFinalizer(sb, func(s *SecureBytes) { s.Zero() })
+	return sb
+}
+
+// Len returns the number of bytes held
+func (s *SecureBytes) Len() int {
+	return len(s.buf)
+}
+
+// Zero overwrites the underlying buffer with zeroes. It is safe to call
+// more than once
+func (s *SecureBytes) Zero() {
+	for i := range s.buf {
+		s.buf[i] = 0
+	}
+}
+
+// WithBytes calls fn with the underlying secret bytes. fn must not retain
+// the slice beyond the call, since it may be zeroed concurrently by the
+// finalizer once s becomes unreachable
+func (s *SecureBytes) WithBytes(fn func([]byte)) {
+	fn(s.buf)
+	runtime.KeepAlive(s)
+}
+
+// CopyBytes returns a fresh copy of the secret bytes. Prefer WithBytes when
+// possible; CopyBytes exists for interop with APIs that require a []byte
+// and defeats the purpose of SecureBytes if the caller doesn't zero the
+// copy when done
+func (s *SecureBytes) CopyBytes() []byte {
+	out := make([]byte, len(s.buf))
+	copy(out, s.buf)
+	runtime.KeepAlive(s)
+	return out
+}